@@ -1,12 +1,12 @@
 package sample1
 
 import (
-	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
 // PriceService is a service that we can use to get prices for the items
@@ -21,68 +21,167 @@ type PriceService interface {
 type TransparentCache struct {
 	actualPriceService PriceService
 	maxAge             time.Duration
-	prices             sync.Map
+	store              Store
+	// sf coalesces concurrent cache misses for the same itemCode into a
+	// single call to actualPriceService, so N goroutines racing on a miss
+	// share one upstream response instead of firing N requests.
+	sf singleflight.Group
+	// cleanupInterval, when non-zero, starts a background janitor that
+	// proactively purges expired entries instead of relying on lazy
+	// eviction from GetPriceFor. See janitor.go.
+	cleanupInterval time.Duration
+	onEvicted       func(itemCode string, price float64)
+	stopCh          chan struct{}
+	closeOnce       sync.Once
+	// maxEntries, when non-zero and no explicit Store was provided via
+	// WithStore, makes NewTransparentCache use an LRUStore bounded to that
+	// many entries instead of the default unbounded MemoryStore.
+	maxEntries    int
+	storeExplicit bool
+	// maxConcurrency, when non-zero, caps the number of in-flight
+	// actualPriceService calls GetPricesFor will make at once.
+	maxConcurrency int
+	// refreshAfter and staleWhileError implement refresh-ahead /
+	// stale-while-revalidate: see refresh.go.
+	refreshAfter    time.Duration
+	staleWhileError time.Duration
+	// refreshing tracks itemCodes with a background refresh already in
+	// flight, so a hot key under concurrent load spawns at most one
+	// refresh goroutine instead of one per request. See refresh.go.
+	refreshing sync.Map
+	// expirationStrategy controls whether maxAge is measured from first
+	// store (FirstSeen, the default) or from last hit (LastSeen). See
+	// strategy.go.
+	expirationStrategy ExpirationStrategy
+	// metrics records cache activity for observability; it defaults to
+	// NopMetrics. See metrics.go.
+	metrics Metrics
 }
 
-// PriceCacheEntry represents a cache entry
+// PriceCacheEntry represents a cache entry. Its fields are exported so that
+// non-memory Store implementations can serialize it (e.g. with encoding/json).
 type PriceCacheEntry struct {
-	price     float64
-	timestamp time.Time
+	Price     float64
+	Timestamp time.Time
 }
 
-func NewTransparentCache(actualPriceService PriceService, maxAge time.Duration) *TransparentCache {
-	return &TransparentCache{
+// NewTransparentCache builds a TransparentCache backed by a MemoryStore,
+// or by an LRUStore if WithMaxEntries is passed, unless overridden with
+// WithStore. If WithCleanupInterval is passed, a
+// background janitor is started; call Close when done with the cache to
+// stop it.
+func NewTransparentCache(actualPriceService PriceService, maxAge time.Duration, opts ...Option) *TransparentCache {
+	c := &TransparentCache{
 		actualPriceService: actualPriceService,
 		maxAge:             maxAge,
+		store:              NewMemoryStore(),
+		metrics:            NopMetrics{},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxEntries > 0 && !c.storeExplicit {
+		c.store = NewLRUStore(c.maxEntries, func(key string, entry PriceCacheEntry) {
+			c.evict(key, entry.Price)
+		})
+	}
+	if c.cleanupInterval > 0 {
+		c.stopCh = make(chan struct{})
+		go c.runJanitor(c.cleanupInterval)
+	}
+	return c
 }
 
 // GetPriceFor gets the price for the item, either from the cache or the actual service if it was not cached or too old
 func (c *TransparentCache) GetPriceFor(itemCode string) (float64, error) {
-	var priceCacheEntry PriceCacheEntry
-	var castSuccessful bool
 	now := time.Now().UTC()
-	retrievedPriceCacheEntry, ok := c.prices.Load(itemCode)
-	if ok {
-		if priceCacheEntry, castSuccessful = retrievedPriceCacheEntry.(PriceCacheEntry); !castSuccessful {
-			return 0, errors.New("error when casting")
+	if entry, ok := c.store.Get(itemCode); ok {
+		age := now.Sub(entry.Timestamp)
+		if age < c.maxAge {
+			// entry is still valid; refresh it in the background if it has
+			// crossed refreshAfter, so the next caller gets a fresh value
+			// without paying the upstream latency itself.
+			if c.refreshAfter > 0 && age >= c.refreshAfter {
+				c.refreshAsync(itemCode)
+			}
+			if c.expirationStrategy == LastSeen {
+				c.touch(itemCode, entry, now)
+			}
+			c.metrics.IncHit()
+			return entry.Price, nil
+		}
+		if c.staleWhileError > 0 && age < c.maxAge+c.staleWhileError {
+			// entry is past maxAge but still within the stale-while-error
+			// grace period; keep serving it while a refresh is attempted,
+			// instead of evicting and forcing this caller to wait on one.
+			c.refreshAsync(itemCode)
+			c.metrics.IncHit()
+			return entry.Price, nil
 		}
-		// check if priceCacheEntry is still valid
-		expDate := priceCacheEntry.timestamp.Add(c.maxAge)
-		if now.Before(expDate) {
-			return retrievedPriceCacheEntry.(PriceCacheEntry).price, nil
+		// entry is expired past any stale grace period; time to evict it.
+		// DeleteIfUnchanged only reports success to the goroutine that
+		// actually removed it, so when several callers race on the same
+		// just-expired entry, evict (and therefore OnEvicted/IncEviction)
+		// fires exactly once instead of once per racing caller.
+		if c.store.DeleteIfUnchanged(itemCode, entry) {
+			c.evict(itemCode, entry.Price)
 		}
-		// priceCacheEntry is expired; time to evict it from cache
-		c.prices.Delete(itemCode)
 	}
-	price, err := c.actualPriceService.GetPriceFor(itemCode)
-	if err != nil {
-		return 0, fmt.Errorf("getting price from service : %v", err.Error())
+	c.metrics.IncMiss()
+	return c.fetchAndStore(itemCode)
+}
+
+// fetchAndStore calls actualPriceService and stores the result, coalescing
+// concurrent calls for the same itemCode via singleflight so only one of
+// them actually hits actualPriceService.
+func (c *TransparentCache) fetchAndStore(itemCode string) (float64, error) {
+	// ran is set only in the caller whose function singleflight actually
+	// executed; singleflight reports shared=true to that caller too.
+	ran := false
+	v, err, shared := c.sf.Do(itemCode, func() (interface{}, error) {
+		ran = true
+		start := time.Now()
+		price, err := c.actualPriceService.GetPriceFor(itemCode)
+		c.metrics.ObserveUpstreamLatency(time.Since(start))
+		if err != nil {
+			c.metrics.IncUpstreamError()
+			return nil, fmt.Errorf("getting price from service : %v", err.Error())
+		}
+		entry := PriceCacheEntry{
+			Price:     price,
+			Timestamp: time.Now().UTC(),
+		}
+		c.store.Set(itemCode, entry)
+		c.metrics.SetEntryCount(c.store.Len())
+		return entry.Price, nil
+	})
+	if shared && !ran {
+		c.metrics.IncCoalesced()
 	}
-	priceCacheEntry = PriceCacheEntry{
-		price:     price,
-		timestamp: now,
+	if err != nil {
+		return 0, err
 	}
-	c.prices.Store(itemCode, priceCacheEntry)
-	return priceCacheEntry.price, nil
+	return v.(float64), nil
 }
 
 // GetPricesFor gets the prices for several items at once, some might be found in the cache, others might not
 // If any of the operations returns an error, it should return an error as well
+// results[i] is always the price for itemCodes[i], regardless of the order the
+// underlying goroutines finish in.
 func (c *TransparentCache) GetPricesFor(itemCodes ...string) ([]float64, error) {
 	var g errgroup.Group
-	var mu sync.Mutex
-	var results []float64
-	for _, itemCode := range itemCodes {
-		ic := itemCode
+	if c.maxConcurrency > 0 {
+		g.SetLimit(c.maxConcurrency)
+	}
+	results := make([]float64, len(itemCodes))
+	for i, itemCode := range itemCodes {
+		i, itemCode := i, itemCode
 		g.Go(func() error {
-			price, err := c.GetPriceFor(ic)
+			price, err := c.GetPriceFor(itemCode)
 			if err != nil {
 				return err
 			}
-			mu.Lock()
-			results = append(results, price)
-			mu.Unlock()
+			results[i] = price
 			return nil
 		})
 	}
@@ -0,0 +1,165 @@
+package sample1
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakePriceService is a PriceService test double. calls counts how many
+// times GetPriceFor actually ran, so tests can assert on coalescing and
+// miss/hit behavior.
+type fakePriceService struct {
+	calls int32
+	delay time.Duration
+	price float64
+	err   error
+}
+
+func (s *fakePriceService) GetPriceFor(itemCode string) (float64, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if s.delay > 0 {
+		time.Sleep(s.delay)
+	}
+	if s.err != nil {
+		return 0, s.err
+	}
+	return s.price, nil
+}
+
+func TestGetPriceFor_CoalescesConcurrentMisses(t *testing.T) {
+	svc := &fakePriceService{price: 42, delay: 50 * time.Millisecond}
+	cache := NewTransparentCache(svc, time.Minute)
+
+	const n = 20
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			price, err := cache.GetPriceFor("ITEM1")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if price != 42 {
+				t.Errorf("got price %v, want 42", price)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&svc.calls); got != 1 {
+		t.Errorf("actualPriceService called %d times, want 1", got)
+	}
+}
+
+// TestGetPriceFor_LazyExpiryEvictsExactlyOnce guards against the race where
+// several goroutines all read the same just-expired entry before any of
+// them deletes it: without a compare-and-delete, every one of them would
+// call evict, inflating OnEvicted/IncEviction well past the number of
+// entries that actually expired.
+func TestGetPriceFor_LazyExpiryEvictsExactlyOnce(t *testing.T) {
+	svc := &fakePriceService{price: 9}
+	var evictions int32
+	cache := NewTransparentCache(svc, 5*time.Millisecond,
+		WithOnEvicted(func(itemCode string, price float64) {
+			atomic.AddInt32(&evictions, 1)
+		}),
+	)
+
+	if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond) // let the entry age past maxAge
+
+	const n = 100
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&evictions); got != 1 {
+		t.Errorf("OnEvicted called %d times, want exactly 1", got)
+	}
+}
+
+// byFirstByteService returns a price derived deterministically from
+// itemCode, so tests can tell whether results[i] actually corresponds to
+// itemCodes[i] rather than just checking they're all equal.
+type byFirstByteService struct{}
+
+func (byFirstByteService) GetPriceFor(itemCode string) (float64, error) {
+	return float64(itemCode[0]), nil
+}
+
+func TestGetPricesFor_PreservesInputOrderUnderMaxConcurrency(t *testing.T) {
+	cache := NewTransparentCache(byFirstByteService{}, time.Minute, WithMaxConcurrency(2))
+
+	itemCodes := []string{"A", "B", "C", "D", "E"}
+	results, err := cache.GetPricesFor(itemCodes...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != len(itemCodes) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(itemCodes))
+	}
+	for i, code := range itemCodes {
+		want := float64(code[0])
+		if results[i] != want {
+			t.Errorf("results[%d] = %v, want %v (price for %q)", i, results[i], want, code)
+		}
+	}
+}
+
+func TestGetPriceFor_ServesStaleWithinStaleWhileErrorWindow(t *testing.T) {
+	svc := &fakePriceService{price: 10}
+	cache := NewTransparentCache(svc, 20*time.Millisecond, WithStaleWhileError(200*time.Millisecond))
+
+	if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// let the entry age past maxAge but stay inside the stale-while-error window
+	time.Sleep(30 * time.Millisecond)
+	svc.err = errors.New("upstream down")
+
+	price, err := cache.GetPriceFor("ITEM1")
+	if err != nil {
+		t.Fatalf("expected stale value instead of an error, got: %v", err)
+	}
+	if price != 10 {
+		t.Fatalf("price = %v, want stale value 10", price)
+	}
+}
+
+func TestGetPriceFor_LastSeenSlidesExpirationOnEveryHit(t *testing.T) {
+	svc := &fakePriceService{price: 5}
+	cache := NewTransparentCache(svc, 30*time.Millisecond, WithExpirationStrategy(LastSeen))
+
+	if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// keep hitting well past maxAge; under LastSeen the entry should never
+	// expire because every hit resets its clock.
+	deadline := time.Now().Add(80 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&svc.calls); got != 1 {
+		t.Fatalf("actualPriceService called %d times, want 1 (entry should never have expired under LastSeen)", got)
+	}
+}
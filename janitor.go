@@ -0,0 +1,60 @@
+package sample1
+
+import "time"
+
+// runJanitor periodically purges expired entries from c.store until Close
+// is called. It uses time.NewTicker rather than time.Tick so the ticker can
+// be stopped, letting this goroutine exit cleanly instead of leaking.
+func (c *TransparentCache) runJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.purgeExpired()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// purgeExpired deletes every entry that has fallen outside GetPriceFor's
+// own staleness window: maxAge, extended by staleWhileError if configured,
+// so the janitor never purges an entry out from under the stale-while-error
+// grace period GetPriceFor is still honoring.
+func (c *TransparentCache) purgeExpired() {
+	now := time.Now().UTC()
+	maxStale := c.maxAge + c.staleWhileError
+	c.store.Range(func(itemCode string, entry PriceCacheEntry) bool {
+		if entry.Timestamp.Add(maxStale).Before(now) {
+			// DeleteIfUnchanged guards against double-reporting the same
+			// eviction if GetPriceFor's lazy expiry races this entry at
+			// the same time.
+			if c.store.DeleteIfUnchanged(itemCode, entry) {
+				c.evict(itemCode, entry.Price)
+			}
+		}
+		return true
+	})
+}
+
+// evict records the eviction metric, updates the entry count, and invokes
+// the OnEvicted callback, if one was set via WithOnEvicted.
+func (c *TransparentCache) evict(itemCode string, price float64) {
+	c.metrics.IncEviction()
+	c.metrics.SetEntryCount(c.store.Len())
+	if c.onEvicted != nil {
+		c.onEvicted(itemCode, price)
+	}
+}
+
+// Close stops the background janitor, if one was started via
+// WithCleanupInterval. It is safe to call multiple times and safe to call
+// even if no janitor was started.
+func (c *TransparentCache) Close() {
+	c.closeOnce.Do(func() {
+		if c.stopCh != nil {
+			close(c.stopCh)
+		}
+	})
+}
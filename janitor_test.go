@@ -0,0 +1,53 @@
+package sample1
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJanitor_PurgesExpiredAndCallsOnEvicted(t *testing.T) {
+	svc := &fakePriceService{price: 7}
+
+	var mu sync.Mutex
+	var evictedKey string
+	var evictedPrice float64
+
+	cache := NewTransparentCache(svc, 20*time.Millisecond,
+		WithCleanupInterval(5*time.Millisecond),
+		WithOnEvicted(func(itemCode string, price float64) {
+			mu.Lock()
+			defer mu.Unlock()
+			evictedKey = itemCode
+			evictedPrice = price
+		}),
+	)
+	defer cache.Close()
+
+	if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cache.store.Len(); got != 1 {
+		t.Fatalf("store.Len() = %d, want 1 before expiry", got)
+	}
+
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		called := evictedKey != ""
+		mu.Unlock()
+		if called {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if evictedKey != "ITEM1" || evictedPrice != 7 {
+		t.Fatalf("OnEvicted called with (%q, %v), want (ITEM1, 7)", evictedKey, evictedPrice)
+	}
+	if got := cache.store.Len(); got != 0 {
+		t.Fatalf("store.Len() = %d, want 0 after janitor purge", got)
+	}
+}
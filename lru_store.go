@@ -0,0 +1,128 @@
+package sample1
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruElement pairs a key with its entry inside the recency list, so
+// eviction can report both to onEvict.
+type lruElement struct {
+	key   string
+	entry PriceCacheEntry
+}
+
+// LRUStore is a Store bounded to maxEntries, evicting the least-recently-used
+// entry whenever a Set would push it over capacity. Every Get hit also
+// counts as a use, moving the entry to the front of the recency list.
+type LRUStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	onEvict    func(key string, entry PriceCacheEntry)
+}
+
+// NewLRUStore builds an LRUStore capped at maxEntries. onEvict, if non-nil,
+// is called for every entry evicted to stay under capacity.
+func NewLRUStore(maxEntries int, onEvict func(key string, entry PriceCacheEntry)) *LRUStore {
+	return &LRUStore{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		onEvict:    onEvict,
+	}
+}
+
+func (l *LRUStore) Get(key string) (PriceCacheEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.items[key]
+	if !ok {
+		return PriceCacheEntry{}, false
+	}
+	l.ll.MoveToFront(el)
+	return el.Value.(*lruElement).entry, true
+}
+
+func (l *LRUStore) Set(key string, entry PriceCacheEntry) {
+	l.mu.Lock()
+	if el, ok := l.items[key]; ok {
+		el.Value.(*lruElement).entry = entry
+		l.ll.MoveToFront(el)
+		l.mu.Unlock()
+		return
+	}
+	el := l.ll.PushFront(&lruElement{key: key, entry: entry})
+	l.items[key] = el
+	var evicted *lruElement
+	if l.maxEntries > 0 && l.ll.Len() > l.maxEntries {
+		evicted = l.evictOldestLocked()
+	}
+	l.mu.Unlock()
+	// onEvict runs with l.mu released, so it can safely call back into this
+	// store (e.g. Len) without deadlocking against this same goroutine.
+	if evicted != nil && l.onEvict != nil {
+		l.onEvict(evicted.key, evicted.entry)
+	}
+}
+
+func (l *LRUStore) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.items[key]; ok {
+		l.removeElement(el)
+	}
+}
+
+func (l *LRUStore) DeleteIfUnchanged(key string, entry PriceCacheEntry) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.items[key]
+	if !ok || el.Value.(*lruElement).entry != entry {
+		return false
+	}
+	l.removeElement(el)
+	return true
+}
+
+func (l *LRUStore) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.ll.Len()
+}
+
+func (l *LRUStore) Range(f func(key string, entry PriceCacheEntry) bool) {
+	l.mu.Lock()
+	snapshot := make([]lruElement, 0, l.ll.Len())
+	for el := l.ll.Front(); el != nil; el = el.Next() {
+		snapshot = append(snapshot, *el.Value.(*lruElement))
+	}
+	l.mu.Unlock()
+	for _, le := range snapshot {
+		if !f(le.key, le.entry) {
+			return
+		}
+	}
+}
+
+// evictOldestLocked removes the least-recently-used entry and returns it,
+// leaving the caller responsible for reporting it to onEvict once l.mu is
+// released. Caller must hold l.mu. Returns nil if the store is empty.
+func (l *LRUStore) evictOldestLocked() *lruElement {
+	el := l.ll.Back()
+	if el == nil {
+		return nil
+	}
+	le := *el.Value.(*lruElement)
+	l.removeElement(el)
+	return &le
+}
+
+// removeElement removes el from both the recency list and the index.
+// Caller must hold l.mu.
+func (l *LRUStore) removeElement(el *list.Element) {
+	le := el.Value.(*lruElement)
+	l.ll.Remove(el)
+	delete(l.items, le.key)
+}
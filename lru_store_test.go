@@ -0,0 +1,57 @@
+package sample1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	var evicted []string
+	s := NewLRUStore(2, func(key string, entry PriceCacheEntry) {
+		evicted = append(evicted, key)
+	})
+
+	s.Set("a", PriceCacheEntry{Price: 1})
+	s.Set("b", PriceCacheEntry{Price: 2})
+	s.Get("a")                            // bump a to the front, leaving b least-recently-used
+	s.Set("c", PriceCacheEntry{Price: 3}) // over capacity; should evict b
+
+	if got := s.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2", got)
+	}
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Fatalf("evicted = %v, want [b]", evicted)
+	}
+	if _, ok := s.Get("b"); ok {
+		t.Fatalf("expected b to have been evicted")
+	}
+	if _, ok := s.Get("a"); !ok {
+		t.Fatalf("expected a to survive (it was the recently-used one)")
+	}
+}
+
+// TestTransparentCache_MaxEntriesDoesNotDeadlock guards against the
+// self-deadlock where LRUStore's capacity eviction called onEvict while
+// still holding its own lock, and onEvict (via TransparentCache.evict)
+// called back into the store.
+func TestTransparentCache_MaxEntriesDoesNotDeadlock(t *testing.T) {
+	svc := &fakePriceService{price: 1}
+	cache := NewTransparentCache(svc, time.Minute, WithMaxEntries(1))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := cache.GetPriceFor("ITEM2"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("GetPriceFor deadlocked when capacity eviction fired")
+	}
+}
@@ -0,0 +1,39 @@
+package sample1
+
+import "time"
+
+// Metrics records cache activity for observability. Implementations must be
+// safe for concurrent use. NopMetrics, the default, discards everything.
+type Metrics interface {
+	// IncHit counts a GetPriceFor call served from the cache, including a
+	// stale entry served within the stale-while-error window.
+	IncHit()
+	// IncMiss counts a GetPriceFor call that had to go to actualPriceService.
+	IncMiss()
+	// IncEviction counts an entry removed for being expired, whether by the
+	// janitor or lazily from GetPriceFor, or removed by an LRUStore (see
+	// WithMaxEntries) to stay under capacity.
+	IncEviction()
+	// IncUpstreamError counts a failed actualPriceService call.
+	IncUpstreamError()
+	// ObserveUpstreamLatency records how long an actualPriceService call took.
+	ObserveUpstreamLatency(d time.Duration)
+	// IncCoalesced counts a call that waited on another in-flight call for
+	// the same itemCode instead of hitting actualPriceService itself.
+	IncCoalesced()
+	// SetEntryCount reports the current number of entries in the store.
+	SetEntryCount(n int)
+}
+
+// NopMetrics is the default Metrics implementation: it discards everything,
+// so the cache is observable only if a real Metrics is wired in via
+// WithMetrics.
+type NopMetrics struct{}
+
+func (NopMetrics) IncHit()                              {}
+func (NopMetrics) IncMiss()                             {}
+func (NopMetrics) IncEviction()                         {}
+func (NopMetrics) IncUpstreamError()                    {}
+func (NopMetrics) ObserveUpstreamLatency(time.Duration) {}
+func (NopMetrics) IncCoalesced()                        {}
+func (NopMetrics) SetEntryCount(int)                    {}
@@ -0,0 +1,67 @@
+package sample1
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetrics is an example Metrics adapter exposing cache activity as
+// Prometheus collectors. Register it once and pass it to WithMetrics.
+type PrometheusMetrics struct {
+	hits            prometheus.Counter
+	misses          prometheus.Counter
+	evictions       prometheus.Counter
+	upstreamErrors  prometheus.Counter
+	upstreamLatency prometheus.Histogram
+	coalesced       prometheus.Counter
+	entryCount      prometheus.Gauge
+}
+
+// NewPrometheusMetrics builds a PrometheusMetrics and registers all of its
+// collectors on reg.
+func NewPrometheusMetrics(reg prometheus.Registerer, namespace string) *PrometheusMetrics {
+	m := &PrometheusMetrics{
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "cache_hits_total",
+			Help: "Number of GetPriceFor calls served from the cache, including stale entries within the stale-while-error window.",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "cache_misses_total",
+			Help: "Number of GetPriceFor calls that went to the upstream price service.",
+		}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "cache_evictions_total",
+			Help: "Number of cache entries evicted for being expired or to stay under capacity.",
+		}),
+		upstreamErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "cache_upstream_errors_total",
+			Help: "Number of failed calls to the upstream price service.",
+		}),
+		upstreamLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "cache_upstream_latency_seconds",
+			Help:    "Latency of calls to the upstream price service.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		coalesced: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace, Name: "cache_coalesced_total",
+			Help: "Number of calls that shared an in-flight upstream call instead of making their own.",
+		}),
+		entryCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "cache_entries",
+			Help: "Current number of entries in the cache.",
+		}),
+	}
+	reg.MustRegister(m.hits, m.misses, m.evictions, m.upstreamErrors, m.upstreamLatency, m.coalesced, m.entryCount)
+	return m
+}
+
+func (m *PrometheusMetrics) IncHit()           { m.hits.Inc() }
+func (m *PrometheusMetrics) IncMiss()          { m.misses.Inc() }
+func (m *PrometheusMetrics) IncEviction()      { m.evictions.Inc() }
+func (m *PrometheusMetrics) IncUpstreamError() { m.upstreamErrors.Inc() }
+func (m *PrometheusMetrics) ObserveUpstreamLatency(d time.Duration) {
+	m.upstreamLatency.Observe(d.Seconds())
+}
+func (m *PrometheusMetrics) IncCoalesced()       { m.coalesced.Inc() }
+func (m *PrometheusMetrics) SetEntryCount(n int) { m.entryCount.Set(float64(n)) }
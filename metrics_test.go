@@ -0,0 +1,40 @@
+package sample1
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeMetrics is a Metrics test double recording counts for assertions.
+type fakeMetrics struct {
+	hits, misses, evictions, upstreamErrors, coalesced int32
+}
+
+func (m *fakeMetrics) IncHit()                              { atomic.AddInt32(&m.hits, 1) }
+func (m *fakeMetrics) IncMiss()                             { atomic.AddInt32(&m.misses, 1) }
+func (m *fakeMetrics) IncEviction()                         { atomic.AddInt32(&m.evictions, 1) }
+func (m *fakeMetrics) IncUpstreamError()                    { atomic.AddInt32(&m.upstreamErrors, 1) }
+func (m *fakeMetrics) ObserveUpstreamLatency(time.Duration) {}
+func (m *fakeMetrics) IncCoalesced()                        { atomic.AddInt32(&m.coalesced, 1) }
+func (m *fakeMetrics) SetEntryCount(int)                    {}
+
+func TestGetPriceFor_RecordsHitAndMissMetrics(t *testing.T) {
+	svc := &fakePriceService{price: 3}
+	metrics := &fakeMetrics{}
+	cache := NewTransparentCache(svc, time.Minute, WithMetrics(metrics))
+
+	if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := cache.GetPriceFor("ITEM1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&metrics.misses); got != 1 {
+		t.Errorf("misses = %d, want 1", got)
+	}
+	if got := atomic.LoadInt32(&metrics.hits); got != 1 {
+		t.Errorf("hits = %d, want 1", got)
+	}
+}
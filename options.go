@@ -0,0 +1,92 @@
+package sample1
+
+import "time"
+
+// Option configures a TransparentCache at construction time.
+type Option func(*TransparentCache)
+
+// WithStore overrides the default in-memory Store, e.g. to share a cache
+// across instances via Redis or to persist it across restarts. It takes
+// precedence over WithMaxEntries, since capacity bounding is a property of
+// the default MemoryStore/LRUStore choice, not of a caller-supplied Store.
+func WithStore(store Store) Option {
+	return func(c *TransparentCache) {
+		c.store = store
+		c.storeExplicit = true
+	}
+}
+
+// WithMaxEntries bounds the cache to at most n entries, evicting the
+// least-recently-used one whenever Set would push it over capacity. It has
+// no effect if combined with WithStore, which always wins.
+func WithMaxEntries(n int) Option {
+	return func(c *TransparentCache) {
+		c.maxEntries = n
+	}
+}
+
+// WithMaxConcurrency caps the number of in-flight actualPriceService calls
+// GetPricesFor makes at once, so a request for thousands of item codes
+// doesn't spawn thousands of concurrent goroutines hammering it.
+func WithMaxConcurrency(n int) Option {
+	return func(c *TransparentCache) {
+		c.maxConcurrency = n
+	}
+}
+
+// WithRefreshAfter enables refresh-ahead: once a cached entry's age passes
+// refreshAfter (but is still under maxAge), GetPriceFor returns the cached
+// value immediately and kicks off an asynchronous refresh, so hot keys stay
+// warm without callers paying upstream latency.
+func WithRefreshAfter(refreshAfter time.Duration) Option {
+	return func(c *TransparentCache) {
+		c.refreshAfter = refreshAfter
+	}
+}
+
+// WithStaleWhileError extends how long an entry keeps serving stale data
+// past maxAge: until maxAge+staleWhileError, GetPriceFor returns the stale
+// value and starts a background refresh, and the entry keeps serving for as
+// long as those refreshes fail, instead of being evicted the moment maxAge
+// is reached.
+func WithStaleWhileError(staleWhileError time.Duration) Option {
+	return func(c *TransparentCache) {
+		c.staleWhileError = staleWhileError
+	}
+}
+
+// WithExpirationStrategy selects whether maxAge is measured from when an
+// entry was first stored (FirstSeen, the default) or from its last hit
+// (LastSeen). See ExpirationStrategy for the tradeoff.
+func WithExpirationStrategy(strategy ExpirationStrategy) Option {
+	return func(c *TransparentCache) {
+		c.expirationStrategy = strategy
+	}
+}
+
+// WithMetrics wires a Metrics implementation into the cache, so hits,
+// misses, evictions, upstream errors/latency, singleflight coalescing and
+// entry count are all observable. Defaults to NopMetrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *TransparentCache) {
+		c.metrics = metrics
+	}
+}
+
+// WithCleanupInterval starts a background janitor that proactively purges
+// expired entries every interval, instead of relying solely on lazy
+// eviction from GetPriceFor. Call Close on the cache to stop it.
+func WithCleanupInterval(interval time.Duration) Option {
+	return func(c *TransparentCache) {
+		c.cleanupInterval = interval
+	}
+}
+
+// WithOnEvicted registers a callback invoked whenever an entry is evicted,
+// whether lazily from GetPriceFor, by the background janitor, or by the
+// LRUStore that WithMaxEntries sets up to stay under capacity.
+func WithOnEvicted(onEvicted func(itemCode string, price float64)) Option {
+	return func(c *TransparentCache) {
+		c.onEvicted = onEvicted
+	}
+}
@@ -0,0 +1,17 @@
+package sample1
+
+// refreshAsync kicks off a background refresh of itemCode, unless one is
+// already in flight: a hot key under concurrent load would otherwise spawn
+// one goroutine per request, all piling up inside fetchAndStore's
+// singleflight group. Its result is discarded: on success the cache is
+// updated for the next reader; on failure the existing entry is left
+// untouched and keeps serving until it falls outside maxAge+staleWhileError.
+func (c *TransparentCache) refreshAsync(itemCode string) {
+	if _, alreadyRefreshing := c.refreshing.LoadOrStore(itemCode, struct{}{}); alreadyRefreshing {
+		return
+	}
+	go func() {
+		defer c.refreshing.Delete(itemCode)
+		_, _ = c.fetchAndStore(itemCode)
+	}()
+}
@@ -0,0 +1,71 @@
+package sample1
+
+import "sync"
+
+// Store is the persistence backend behind a TransparentCache. Implementations
+// must be safe for concurrent use.
+type Store interface {
+	// Get returns the entry stored under key, and whether it was found.
+	Get(key string) (PriceCacheEntry, bool)
+	// Set stores entry under key, overwriting any previous value.
+	Set(key string, entry PriceCacheEntry)
+	// Delete removes key, if present.
+	Delete(key string)
+	// DeleteIfUnchanged removes key, but only if its currently stored entry
+	// is still exactly entry. It reports whether this call was the one
+	// that performed the delete, so callers racing on the same stale entry
+	// (e.g. concurrent lazy-expiry in GetPriceFor) can tell which one of
+	// them actually evicted it.
+	DeleteIfUnchanged(key string, entry PriceCacheEntry) bool
+	// Len returns the number of entries currently stored.
+	Len() int
+	// Range calls f for every stored entry. Iteration stops early if f
+	// returns false.
+	Range(f func(key string, entry PriceCacheEntry) bool)
+}
+
+// MemoryStore is the default Store, backed by a sync.Map. It does not
+// survive restarts and is not shared across process instances.
+type MemoryStore struct {
+	entries sync.Map
+}
+
+// NewMemoryStore builds an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (m *MemoryStore) Get(key string) (PriceCacheEntry, bool) {
+	v, ok := m.entries.Load(key)
+	if !ok {
+		return PriceCacheEntry{}, false
+	}
+	return v.(PriceCacheEntry), true
+}
+
+func (m *MemoryStore) Set(key string, entry PriceCacheEntry) {
+	m.entries.Store(key, entry)
+}
+
+func (m *MemoryStore) Delete(key string) {
+	m.entries.Delete(key)
+}
+
+func (m *MemoryStore) DeleteIfUnchanged(key string, entry PriceCacheEntry) bool {
+	return m.entries.CompareAndDelete(key, entry)
+}
+
+func (m *MemoryStore) Len() int {
+	count := 0
+	m.entries.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func (m *MemoryStore) Range(f func(key string, entry PriceCacheEntry) bool) {
+	m.entries.Range(func(k, v interface{}) bool {
+		return f(k.(string), v.(PriceCacheEntry))
+	})
+}
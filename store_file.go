@@ -0,0 +1,117 @@
+package sample1
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore is a Store that persists entries to a single JSON file on disk,
+// so the cache survives process restarts. It keeps an in-memory copy for
+// reads and rewrites the file on every mutation, so it is best suited to
+// small to medium keyspaces.
+type FileStore struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]PriceCacheEntry
+}
+
+// NewFileStore loads entries from path if it already exists, or starts
+// empty otherwise.
+func NewFileStore(path string) (*FileStore, error) {
+	fs := &FileStore{path: path, entries: map[string]PriceCacheEntry{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fs, nil
+		}
+		return nil, fmt.Errorf("reading file store %q: %v", path, err.Error())
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &fs.entries); err != nil {
+			return nil, fmt.Errorf("decoding file store %q: %v", path, err.Error())
+		}
+	}
+	return fs, nil
+}
+
+func (f *FileStore) Get(key string) (PriceCacheEntry, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.entries[key]
+	return entry, ok
+}
+
+func (f *FileStore) Set(key string, entry PriceCacheEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries[key] = entry
+	f.persistLocked()
+}
+
+func (f *FileStore) Delete(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.entries, key)
+	f.persistLocked()
+}
+
+func (f *FileStore) DeleteIfUnchanged(key string, entry PriceCacheEntry) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.entries[key] != entry {
+		return false
+	}
+	delete(f.entries, key)
+	f.persistLocked()
+	return true
+}
+
+func (f *FileStore) Len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.entries)
+}
+
+func (f *FileStore) Range(fn func(key string, entry PriceCacheEntry) bool) {
+	f.mu.Lock()
+	snapshot := make(map[string]PriceCacheEntry, len(f.entries))
+	for k, v := range f.entries {
+		snapshot[k] = v
+	}
+	f.mu.Unlock()
+	for k, v := range snapshot {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// persistLocked rewrites the backing file with the current entries. It
+// writes to a temp file in the same directory and renames it into place, so
+// a crash mid-write leaves the previous, still-valid file behind instead of
+// a truncated one that NewFileStore can't decode. Callers must hold f.mu.
+func (f *FileStore) persistLocked() {
+	data, err := json.Marshal(f.entries)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), f.path)
+}
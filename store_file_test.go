@@ -0,0 +1,104 @@
+package sample1
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStore_GetSetDeleteLenRange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+
+	if _, ok := s.Get("a"); ok {
+		t.Fatalf("expected miss on empty store")
+	}
+
+	entry := PriceCacheEntry{Price: 1.5, Timestamp: time.Now().UTC()}
+	s.Set("a", entry)
+
+	got, ok := s.Get("a")
+	if !ok || got.Price != entry.Price {
+		t.Fatalf("Get(a) = %+v, %v; want %+v, true", got, ok, entry)
+	}
+	if got := s.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1", got)
+	}
+
+	seen := map[string]PriceCacheEntry{}
+	s.Range(func(key string, e PriceCacheEntry) bool {
+		seen[key] = e
+		return true
+	})
+	if len(seen) != 1 || seen["a"].Price != entry.Price {
+		t.Fatalf("Range saw %+v, want {a: %+v}", seen, entry)
+	}
+
+	s.Delete("a")
+	if _, ok := s.Get("a"); ok {
+		t.Fatalf("expected a to be gone after Delete")
+	}
+	if got := s.Len(); got != 0 {
+		t.Fatalf("Len() after delete = %d, want 0", got)
+	}
+}
+
+// TestFileStore_SurvivesRestart confirms that entries written by one
+// FileStore are readable by a fresh FileStore opened against the same
+// path afterwards, i.e. the JSON serialization round-trips across what a
+// process restart would look like.
+func TestFileStore_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	entry := PriceCacheEntry{Price: 2.25, Timestamp: time.Now().UTC()}
+	first, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	first.Set("a", entry)
+
+	second, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore (reopen): %v", err)
+	}
+	got, ok := second.Get("a")
+	if !ok {
+		t.Fatalf("expected a to survive reopening the store")
+	}
+	if !got.Timestamp.Equal(entry.Timestamp) || got.Price != entry.Price {
+		t.Fatalf("Get(a) after reopen = %+v, want %+v", got, entry)
+	}
+}
+
+// TestFileStore_SurvivesTruncatedWrite checks that a crash mid-write, as
+// persistLocked's temp-file-then-rename would leave it (simulated here by a
+// truncated .tmp-* file next to an already-valid cache file), does not stop
+// NewFileStore from loading the last good file. It does not exercise
+// persistLocked itself failing part-way.
+func TestFileStore_SurvivesTruncatedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	entry := PriceCacheEntry{Price: 3.5, Timestamp: time.Now().UTC()}
+	first, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	first.Set("a", entry)
+
+	if err := os.WriteFile(path+".tmp-stale", []byte(`{"a":`), 0o600); err != nil {
+		t.Fatalf("writing stale tmp file: %v", err)
+	}
+
+	second, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore (reopen) should ignore the truncated tmp file, got: %v", err)
+	}
+	got, ok := second.Get("a")
+	if !ok || got.Price != entry.Price {
+		t.Fatalf("Get(a) after reopen = %+v, %v; want %+v, true", got, ok, entry)
+	}
+}
@@ -0,0 +1,132 @@
+package sample1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a Store backed by Redis, letting multiple TransparentCache
+// instances share the same price cache across processes.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisStore builds a RedisStore. Every key is stored under prefix, so a
+// single Redis instance can be shared by unrelated caches. ttl, when
+// non-zero, is set as the Redis key expiration on every Set, as a backstop
+// in case nothing else is purging old entries from this Redis instance.
+func NewRedisStore(client *redis.Client, prefix string, ttl time.Duration) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix, ttl: ttl}
+}
+
+func (r *RedisStore) key(itemCode string) string {
+	return r.prefix + itemCode
+}
+
+func (r *RedisStore) Get(key string) (PriceCacheEntry, bool) {
+	data, err := r.client.Get(context.Background(), r.key(key)).Bytes()
+	if err != nil {
+		return PriceCacheEntry{}, false
+	}
+	var entry PriceCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return PriceCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (r *RedisStore) Set(key string, entry PriceCacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	r.client.Set(context.Background(), r.key(key), data, r.ttl)
+}
+
+func (r *RedisStore) Delete(key string) {
+	r.client.Del(context.Background(), r.key(key))
+}
+
+// DeleteIfUnchanged removes key only if the value currently stored under it
+// still matches entry, using WATCH/MULTI so the check and the delete are
+// atomic against concurrent writers racing on the same key.
+func (r *RedisStore) DeleteIfUnchanged(key string, entry PriceCacheEntry) bool {
+	want, err := json.Marshal(entry)
+	if err != nil {
+		return false
+	}
+	k := r.key(key)
+	ctx := context.Background()
+	deleted := false
+	err = r.client.Watch(ctx, func(tx *redis.Tx) error {
+		got, err := tx.Get(ctx, k).Bytes()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if err == redis.Nil || !bytes.Equal(got, want) {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, k)
+			return nil
+		})
+		if err == nil {
+			deleted = true
+		}
+		return err
+	}, k)
+	if err != nil {
+		return false
+	}
+	return deleted
+}
+
+func (r *RedisStore) Len() int {
+	count := 0
+	r.scanKeys(func(string) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func (r *RedisStore) Range(f func(key string, entry PriceCacheEntry) bool) {
+	r.scanKeys(func(k string) bool {
+		itemCode := strings.TrimPrefix(k, r.prefix)
+		entry, ok := r.Get(itemCode)
+		if !ok {
+			return true
+		}
+		return f(itemCode, entry)
+	})
+}
+
+// scanKeys walks every key under r.prefix using SCAN rather than KEYS, so it
+// doesn't block the Redis server on a large keyspace. It stops early if f
+// returns false.
+func (r *RedisStore) scanKeys(f func(key string) bool) {
+	ctx := context.Background()
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, r.prefix+"*", 100).Result()
+		if err != nil {
+			return
+		}
+		for _, k := range keys {
+			if !f(k) {
+				return
+			}
+		}
+		if next == 0 {
+			return
+		}
+		cursor = next
+	}
+}
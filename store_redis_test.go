@@ -0,0 +1,75 @@
+package sample1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisStore(t *testing.T) *RedisStore {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisStore(client, "prices:", 0)
+}
+
+func TestRedisStore_GetSetDeleteLenRange(t *testing.T) {
+	s := newTestRedisStore(t)
+
+	if _, ok := s.Get("a"); ok {
+		t.Fatalf("expected miss on empty store")
+	}
+
+	entry := PriceCacheEntry{Price: 1.5, Timestamp: time.Now().UTC()}
+	s.Set("a", entry)
+
+	got, ok := s.Get("a")
+	if !ok || got.Price != entry.Price {
+		t.Fatalf("Get(a) = %+v, %v; want %+v, true", got, ok, entry)
+	}
+	if got := s.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1", got)
+	}
+
+	seen := map[string]PriceCacheEntry{}
+	s.Range(func(key string, e PriceCacheEntry) bool {
+		seen[key] = e
+		return true
+	})
+	if len(seen) != 1 || seen["a"].Price != entry.Price {
+		t.Fatalf("Range saw %+v, want {a: %+v}", seen, entry)
+	}
+
+	s.Delete("a")
+	if _, ok := s.Get("a"); ok {
+		t.Fatalf("expected a to be gone after Delete")
+	}
+	if got := s.Len(); got != 0 {
+		t.Fatalf("Len() after delete = %d, want 0", got)
+	}
+}
+
+func TestRedisStore_DeleteIfUnchanged(t *testing.T) {
+	s := newTestRedisStore(t)
+
+	entry := PriceCacheEntry{Price: 2, Timestamp: time.Now().UTC()}
+	s.Set("a", entry)
+
+	stale := PriceCacheEntry{Price: 99, Timestamp: entry.Timestamp.Add(-time.Hour)}
+	if s.DeleteIfUnchanged("a", stale) {
+		t.Fatalf("DeleteIfUnchanged should not delete when the stored entry has moved on")
+	}
+	if _, ok := s.Get("a"); !ok {
+		t.Fatalf("expected a to survive a mismatched DeleteIfUnchanged")
+	}
+
+	if !s.DeleteIfUnchanged("a", entry) {
+		t.Fatalf("DeleteIfUnchanged should delete when the stored entry still matches")
+	}
+	if _, ok := s.Get("a"); ok {
+		t.Fatalf("expected a to be gone after a matching DeleteIfUnchanged")
+	}
+}
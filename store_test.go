@@ -0,0 +1,42 @@
+package sample1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_GetSetDeleteLenRange(t *testing.T) {
+	s := NewMemoryStore()
+
+	if _, ok := s.Get("a"); ok {
+		t.Fatalf("expected miss on empty store")
+	}
+
+	entry := PriceCacheEntry{Price: 1.5, Timestamp: time.Now()}
+	s.Set("a", entry)
+
+	got, ok := s.Get("a")
+	if !ok || got.Price != entry.Price {
+		t.Fatalf("Get(a) = %+v, %v; want %+v, true", got, ok, entry)
+	}
+	if got := s.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1", got)
+	}
+
+	seen := map[string]PriceCacheEntry{}
+	s.Range(func(key string, e PriceCacheEntry) bool {
+		seen[key] = e
+		return true
+	})
+	if len(seen) != 1 || seen["a"].Price != entry.Price {
+		t.Fatalf("Range saw %+v, want {a: %+v}", seen, entry)
+	}
+
+	s.Delete("a")
+	if _, ok := s.Get("a"); ok {
+		t.Fatalf("expected a to be gone after Delete")
+	}
+	if got := s.Len(); got != 0 {
+		t.Fatalf("Len() after delete = %d, want 0", got)
+	}
+}
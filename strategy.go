@@ -0,0 +1,28 @@
+package sample1
+
+import "time"
+
+// ExpirationStrategy controls what Timestamp on a PriceCacheEntry measures,
+// and therefore what maxAge is relative to.
+type ExpirationStrategy int
+
+const (
+	// FirstSeen expires an entry maxAge after it was first stored (the
+	// default). Hits never extend its life, so even a hot key is
+	// eventually refetched.
+	FirstSeen ExpirationStrategy = iota
+	// LastSeen expires an entry maxAge after it was last read, sliding its
+	// expiration forward on every hit. This keeps hot keys permanently
+	// warm, but means a continuously-hit key can serve data that is
+	// arbitrarily older than maxAge relative to when it was first fetched.
+	LastSeen
+)
+
+// touch updates entry's Timestamp to now and writes it back, implementing
+// the sliding expiration of LastSeen. The janitor purges by comparing the
+// same Timestamp field, so it honors whichever strategy is configured
+// without any extra bookkeeping.
+func (c *TransparentCache) touch(itemCode string, entry PriceCacheEntry, now time.Time) {
+	entry.Timestamp = now
+	c.store.Set(itemCode, entry)
+}